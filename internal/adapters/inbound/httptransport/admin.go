@@ -0,0 +1,70 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"marketflow/internal/app"
+	"marketflow/internal/app/exchange"
+	"marketflow/pkg"
+)
+
+// AdminExchanges serves /admin/exchanges and /admin/exchanges/{id},
+// dispatching on method: GET lists, POST registers, DELETE unregisters.
+func (h *Handler) AdminExchanges(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/admin/exchanges":
+		h.adminListExchanges(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/admin/exchanges":
+		h.adminRegisterExchange(w, r)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/exchanges/"):
+		h.adminDeleteExchange(w, r)
+	default:
+		pkg.WriteErrorJSON(w, http.StatusMethodNotAllowed, "unsupported method or path for /admin/exchanges")
+	}
+}
+
+func (h *Handler) adminListExchanges(w http.ResponseWriter, r *http.Request) {
+	pkg.WriteJSON(w, http.StatusOK, h.registry.List())
+}
+
+// adminRegisterExchange handles POST /admin/exchanges with a body of
+// {id, address, protocol, enabled}. It adds the exchange or, if id
+// already exists, replaces its entry, letting the ingestion subsystem
+// spin up or tear down the corresponding reader goroutine without a
+// restart.
+func (h *Handler) adminRegisterExchange(w http.ResponseWriter, r *http.Request) {
+	var ex exchange.Exchange
+	if err := json.NewDecoder(r.Body).Decode(&ex); err != nil {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.registry.Register(ex); err != nil {
+		if appErr, ok := app.IsAppError(err); ok {
+			h.logger.Warn("Register exchange failed", "error", appErr.Message)
+			pkg.WriteErrorJSON(w, appErr.Code, appErr.Message)
+			return
+		}
+		h.logger.Error("Unexpected error registering exchange", "error", err)
+		pkg.WriteErrorJSON(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.Info("Exchange registered", "id", ex.ID, "address", ex.Address)
+	pkg.WriteJSON(w, http.StatusOK, ex)
+}
+
+// adminDeleteExchange handles DELETE /admin/exchanges/{id}.
+func (h *Handler) adminDeleteExchange(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/admin/exchanges/")
+	if id == "" || strings.Contains(id, "/") {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "exchange id is required")
+		return
+	}
+
+	h.registry.Unregister(id)
+	h.logger.Info("Exchange unregistered", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,288 @@
+package httptransport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"marketflow/internal/app"
+	"marketflow/internal/app/model"
+	"marketflow/pkg"
+)
+
+// maxBatchWorkers bounds how many lookups a single batch request runs
+// concurrently against the storage layer.
+const maxBatchWorkers = 10
+
+// batchResponse is the envelope for every batch endpoint: data keyed by
+// symbol then exchange (exchange "" means aggregated across exchanges),
+// plus a per-key error for lookups that failed.
+type batchResponse struct {
+	Data   map[string]map[string]*model.MarketData `json:"data"`
+	Errors map[string]string                       `json:"errors,omitempty"`
+}
+
+// BatchLatestPrice serves GET /prices/latest?symbols=..&exchanges=..,
+// returning every symbol/exchange combination in one round trip instead
+// of forcing the client to issue N×M sequential requests.
+func (h *Handler) BatchLatestPrice(w http.ResponseWriter, r *http.Request) {
+	symbols, exchanges, err := h.parseBatchParams(r.URL.Query())
+	if err != nil {
+		h.writeBatchValidationError(w, err)
+		return
+	}
+
+	data, errs, err := h.svc.GetLatestBatch(r.Context(), symbols, exchanges)
+	if err != nil {
+		h.writeBatchServiceError(w, err)
+		return
+	}
+
+	data, errs = h.remapBatchResult(data, errs)
+	pkg.WriteJSON(w, http.StatusOK, batchResponse{Data: data, Errors: errs})
+}
+
+// BatchHighestPrice serves GET /prices/highest?symbols=..&exchanges=...
+func (h *Handler) BatchHighestPrice(w http.ResponseWriter, r *http.Request) {
+	h.serveBatch(w, r, func(ctx context.Context, exchange, symbol string) (*model.MarketData, error) {
+		if exchange == "" {
+			return h.svc.GetHighestAggregate(ctx, symbol)
+		}
+		return h.svc.GetHighestByExchange(ctx, exchange, symbol)
+	})
+}
+
+// BatchLowestPrice serves GET /prices/lowest?symbols=..&exchanges=...
+func (h *Handler) BatchLowestPrice(w http.ResponseWriter, r *http.Request) {
+	h.serveBatch(w, r, func(ctx context.Context, exchange, symbol string) (*model.MarketData, error) {
+		if exchange == "" {
+			return h.svc.GetLowestAggregate(ctx, symbol)
+		}
+		return h.svc.GetLowestByExchange(ctx, exchange, symbol)
+	})
+}
+
+// BatchAveragePrice serves GET /prices/average?symbols=..&exchanges=...
+func (h *Handler) BatchAveragePrice(w http.ResponseWriter, r *http.Request) {
+	h.serveBatch(w, r, func(ctx context.Context, exchange, symbol string) (*model.MarketData, error) {
+		if exchange == "" {
+			return h.svc.GetAverageAggregate(ctx, symbol)
+		}
+		return h.svc.GetAverageByExchange(ctx, exchange, symbol)
+	})
+}
+
+// fetchFunc resolves a single symbol/exchange pair. exchange == "" means
+// "aggregated across exchanges".
+type fetchFunc func(ctx context.Context, exchange, symbol string) (*model.MarketData, error)
+
+// serveBatch parses and validates the batch params, fans the lookup out
+// across a bounded worker pool, and writes the combined response. Unlike
+// GetLatestBatch, these three endpoints have no dedicated storage-level
+// batch query, so they reuse the existing single-pair service calls.
+func (h *Handler) serveBatch(w http.ResponseWriter, r *http.Request, fetch fetchFunc) {
+	symbols, exchanges, err := h.parseBatchParams(r.URL.Query())
+	if err != nil {
+		h.writeBatchValidationError(w, err)
+		return
+	}
+
+	data, errs := runBatch(r.Context(), symbols, exchanges, fetch)
+	data, errs = h.remapBatchResult(data, errs)
+	pkg.WriteJSON(w, http.StatusOK, batchResponse{Data: data, Errors: errs})
+}
+
+// remapBatchResult translates the exchange dial addresses runBatch and
+// GetLatestBatch key their results by back into the short exchange IDs
+// clients sent, the same translation the single-item handlers already
+// apply to data.Exchange, since both batch paths work in addresses
+// internally.
+func (h *Handler) remapBatchResult(data map[string]map[string]*model.MarketData, errs map[string]string) (map[string]map[string]*model.MarketData, map[string]string) {
+	remappedData := make(map[string]map[string]*model.MarketData, len(data))
+	for symbol, byExchange := range data {
+		out := make(map[string]*model.MarketData, len(byExchange))
+		for exchangeKey, md := range byExchange {
+			if md != nil {
+				if shortID, ok := h.registry.IDForAddress(md.Exchange); ok {
+					md.Exchange = shortID
+				}
+			}
+			out[h.shortExchangeKey(exchangeKey)] = md
+		}
+		remappedData[symbol] = out
+	}
+
+	remappedErrs := make(map[string]string, len(errs))
+	for key, msg := range errs {
+		symbol, exchangeKey, ok := strings.Cut(key, ":")
+		if !ok {
+			remappedErrs[key] = msg
+			continue
+		}
+		remappedErrs[symbol+":"+h.shortExchangeKey(exchangeKey)] = msg
+	}
+
+	return remappedData, remappedErrs
+}
+
+// shortExchangeKey resolves a dial address back to its short exchange ID
+// via the registry, leaving "" (the aggregated-across-exchanges key) and
+// anything the registry no longer recognizes untouched.
+func (h *Handler) shortExchangeKey(exchangeKey string) string {
+	if exchangeKey == "" {
+		return ""
+	}
+	if shortID, ok := h.registry.IDForAddress(exchangeKey); ok {
+		return shortID
+	}
+	return exchangeKey
+}
+
+func runBatch(ctx context.Context, symbols, exchanges []string, fetch fetchFunc) (map[string]map[string]*model.MarketData, map[string]string) {
+	type job struct {
+		symbol, exchange string
+	}
+
+	exchangeKeys := exchanges
+	if len(exchangeKeys) == 0 {
+		exchangeKeys = []string{""}
+	}
+
+	jobs := make(chan job, len(symbols)*len(exchangeKeys))
+	for _, s := range symbols {
+		for _, e := range exchangeKeys {
+			jobs <- job{symbol: s, exchange: e}
+		}
+	}
+	close(jobs)
+
+	var (
+		mu      sync.Mutex
+		data    = make(map[string]map[string]*model.MarketData)
+		errs    = make(map[string]string)
+		wg      sync.WaitGroup
+		workers = maxBatchWorkers
+	)
+	if workers > len(symbols)*len(exchangeKeys) {
+		workers = len(symbols) * len(exchangeKeys)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result, err := fetch(ctx, j.exchange, j.symbol)
+				key := j.symbol + ":" + j.exchange
+
+				mu.Lock()
+				if err != nil {
+					errs[key] = errorMessage(err)
+				} else {
+					if data[j.symbol] == nil {
+						data[j.symbol] = make(map[string]*model.MarketData)
+					}
+					data[j.symbol][j.exchange] = result
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return data, errs
+}
+
+func errorMessage(err error) string {
+	if appErr, ok := app.IsAppError(err); ok {
+		return appErr.Message
+	}
+	return "internal error"
+}
+
+// parseBatchParams splits and validates the symbols/exchanges query
+// params, enforcing the configured cap and collecting every invalid
+// element into a single structured 400 rather than failing on the
+// first one.
+func (h *Handler) parseBatchParams(query url.Values) (symbols, exchanges []string, err error) {
+	rawSymbols := splitCSV(query.Get("symbols"))
+	rawExchanges := splitCSV(query.Get("exchanges"))
+
+	if len(rawSymbols) == 0 {
+		return nil, nil, app.BadRequest("symbols is required")
+	}
+	if len(rawSymbols) > app.MaxBatchSymbols {
+		return nil, nil, app.BadRequest(fmt.Sprintf("too many symbols: max %d", app.MaxBatchSymbols))
+	}
+	if len(rawExchanges) > app.MaxBatchExchanges {
+		return nil, nil, app.BadRequest(fmt.Sprintf("too many exchanges: max %d", app.MaxBatchExchanges))
+	}
+
+	var invalid []string
+
+	symbols = make([]string, 0, len(rawSymbols))
+	for _, s := range rawSymbols {
+		normalized, err := app.ValidateSymbol(s)
+		if err != nil {
+			invalid = append(invalid, "symbol "+s)
+			continue
+		}
+		symbols = append(symbols, normalized)
+	}
+
+	exchanges = make([]string, 0, len(rawExchanges))
+	for _, e := range rawExchanges {
+		full, err := h.validateExchange(e)
+		if err != nil {
+			invalid = append(invalid, "exchange "+e)
+			continue
+		}
+		if full != "" {
+			exchanges = append(exchanges, full)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, nil, app.BadRequest("invalid input: " + strings.Join(invalid, ", "))
+	}
+
+	return symbols, exchanges, nil
+}
+
+func splitCSV(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (h *Handler) writeBatchValidationError(w http.ResponseWriter, err error) {
+	if appErr, ok := app.IsAppError(err); ok {
+		h.logger.Warn("Batch request validation failed", "error", appErr.Message)
+		pkg.WriteErrorJSON(w, appErr.Code, appErr.Message)
+		return
+	}
+	h.logger.Error("Unexpected error validating batch request", "error", err)
+	pkg.WriteErrorJSON(w, http.StatusInternalServerError, "Internal server error")
+}
+
+func (h *Handler) writeBatchServiceError(w http.ResponseWriter, err error) {
+	if appErr, ok := app.IsAppError(err); ok {
+		h.logger.Warn("Batch request failed", "error", appErr.Message)
+		pkg.WriteErrorJSON(w, appErr.Code, appErr.Message)
+		return
+	}
+	h.logger.Error("Unexpected error serving batch request", "error", err)
+	pkg.WriteErrorJSON(w, http.StatusInternalServerError, "Internal server error")
+}
@@ -0,0 +1,50 @@
+package httptransport
+
+import (
+	"context"
+	"testing"
+
+	"marketflow/internal/app"
+	"marketflow/internal/app/model"
+)
+
+func TestRunBatchReturnsPartialFailures(t *testing.T) {
+	fetch := func(ctx context.Context, exchange, symbol string) (*model.MarketData, error) {
+		if symbol == "ETH" {
+			return nil, app.UnknownSymbol("no data for ETH")
+		}
+		return &model.MarketData{Symbol: symbol, Exchange: exchange, Price: 100}, nil
+	}
+
+	data, errs := runBatch(context.Background(), []string{"BTC", "ETH"}, []string{"exchange1:40101"}, fetch)
+
+	if got := data["BTC"]["exchange1:40101"]; got == nil || got.Price != 100 {
+		t.Fatalf("expected BTC result, got %+v", got)
+	}
+	if _, ok := data["ETH"]; ok {
+		t.Fatalf("expected no data entry for the failed ETH lookup, got %+v", data["ETH"])
+	}
+
+	want := "no data for ETH"
+	if got := errs["ETH:exchange1:40101"]; got != want {
+		t.Fatalf("expected error %q for ETH, got %q", want, got)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly 1 error entry, got %d: %+v", len(errs), errs)
+	}
+}
+
+func TestRunBatchDefaultsToAggregatedExchange(t *testing.T) {
+	fetch := func(ctx context.Context, exchange, symbol string) (*model.MarketData, error) {
+		return &model.MarketData{Symbol: symbol, Exchange: exchange}, nil
+	}
+
+	data, errs := runBatch(context.Background(), []string{"BTC"}, nil, fetch)
+
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %+v", errs)
+	}
+	if _, ok := data["BTC"][""]; !ok {
+		t.Fatalf("expected the aggregated (\"\") key to be populated, got %+v", data["BTC"])
+	}
+}
@@ -0,0 +1,88 @@
+package httptransport
+
+import (
+	"net/http"
+	"strings"
+
+	"marketflow/internal/app"
+	"marketflow/pkg"
+)
+
+// Candles serves GET /prices/candles/{symbol} and
+// GET /prices/candles/{exchange}/{symbol}. It returns OHLCV bars
+// aggregated over the requested interval from tick history; intervals
+// with no ticks are omitted from the response rather than returned as
+// empty bars.
+func (h *Handler) Candles(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	var (
+		symbol   string
+		exchange string
+	)
+
+	switch len(parts) {
+	case 3:
+		symbol = parts[2]
+	case 4:
+		var err error
+		exchange, err = h.validateExchange(parts[2])
+		if err != nil {
+			h.logger.Warn("Invalid exchange", "exchange", parts[2])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		symbol = parts[3]
+	default:
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "Invalid path")
+		return
+	}
+
+	symbol, err := app.ValidateSymbol(symbol)
+	if err != nil {
+		h.logger.Warn("Invalid symbol", "symbol", symbol)
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	interval := query.Get("interval")
+	if _, ok := app.ValidIntervals[interval]; !ok {
+		h.logger.Warn("Invalid interval", "interval", interval)
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "interval must be one of 1m, 5m, 15m, 1h, 4h, 1d")
+		return
+	}
+
+	start, err := app.ParseTimestamp(query.Get("start"))
+	if err != nil {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "invalid start: "+err.Error())
+		return
+	}
+	end, err := app.ParseTimestamp(query.Get("end"))
+	if err != nil {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "invalid end: "+err.Error())
+		return
+	}
+	if !end.After(start) {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "end must be after start")
+		return
+	}
+	if end.Sub(start) > app.MaxCandleRange {
+		pkg.WriteErrorJSON(w, http.StatusBadRequest, "requested range exceeds the maximum of "+app.MaxCandleRange.String())
+		return
+	}
+
+	candles, err := h.svc.GetCandles(r.Context(), exchange, symbol, interval, start, end)
+	if err != nil {
+		if appErr, ok := app.IsAppError(err); ok {
+			h.logger.Warn("Candles error", "symbol", symbol, "exchange", exchange, "error", appErr.Message)
+			pkg.WriteErrorJSON(w, appErr.Code, appErr.Message)
+			return
+		}
+		h.logger.Error("Unexpected error", "symbol", symbol, "exchange", exchange, "error", err)
+		pkg.WriteErrorJSON(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pkg.WriteJSON(w, http.StatusOK, app.CandlesOrEmpty(candles))
+}
@@ -4,9 +4,9 @@ import (
 	"html/template"
 	"net/http"
 	"strings"
-	"time"
 
 	"marketflow/internal/app"
+	"marketflow/internal/app/exchange"
 	"marketflow/internal/app/model"
 	ports "marketflow/internal/ports/inbound"
 	"marketflow/pkg"
@@ -15,32 +15,22 @@ import (
 
 type Handler struct {
 	svc       ports.APIPorts
+	registry  *exchange.Registry
 	templates *template.Template
 	logger    *logger.CustomLogger
 }
 
-func NewHandler(svc ports.APIPorts, logger *logger.CustomLogger) (*Handler, error) {
+func NewHandler(svc ports.APIPorts, registry *exchange.Registry, logger *logger.CustomLogger) (*Handler, error) {
 	tmpl := template.Must(template.ParseGlob("web/templates/*.html"))
 
 	return &Handler{
 		svc:       svc,
+		registry:  registry,
 		templates: tmpl,
 		logger:    logger,
 	}, nil
 }
 
-var idToAddr = map[string]string{
-	"exchange1": "exchange1:40101",
-	"exchange2": "exchange2:40102",
-	"exchange3": "exchange3:40103",
-}
-
-var addrToID = map[string]string{
-	"exchange1:40101": "exchange1",
-	"exchange2:40102": "exchange2",
-	"exchange3:40103": "exchange3",
-}
-
 func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("Rendering index page")
 	if err := h.templates.ExecuteTemplate(w, "index.html", nil); err != nil {
@@ -93,11 +83,11 @@ func (h *Handler) LatestPrice(w http.ResponseWriter, r *http.Request) {
 
 	switch len(parts) {
 	case 3:
-		symbol = strings.ToUpper(parts[2])
-
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[2])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[2])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -105,17 +95,18 @@ func (h *Handler) LatestPrice(w http.ResponseWriter, r *http.Request) {
 
 	case 4:
 		exchange = parts[2]
-		exchange, err = validateExchange(exchange)
+		exchange, err = h.validateExchange(exchange)
 		if err != nil {
 			h.logger.Warn("Invalid exchange", "exchange", parts[2])
 			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		symbol = strings.ToUpper(parts[3])
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[3])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[3])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -138,7 +129,7 @@ func (h *Handler) LatestPrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if shortID, ok := addrToID[data.Exchange]; ok {
+	if shortID, ok := h.registry.IDForAddress(data.Exchange); ok {
 		data.Exchange = shortID
 	}
 
@@ -156,7 +147,7 @@ func (h *Handler) HighestPrice(w http.ResponseWriter, r *http.Request) {
 		err      error
 	)
 
-	period, err := parsePeriod(query.Get("period"))
+	period, err := app.ParsePeriod(query.Get("period"))
 	if err != nil {
 		h.logger.Warn("Invalid period", "error", err)
 		pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
@@ -165,11 +156,11 @@ func (h *Handler) HighestPrice(w http.ResponseWriter, r *http.Request) {
 
 	switch len(parts) {
 	case 3:
-		symbol = strings.ToUpper(parts[2])
-
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[2])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[2])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -181,17 +172,18 @@ func (h *Handler) HighestPrice(w http.ResponseWriter, r *http.Request) {
 
 	case 4:
 		exchange = parts[2]
-		exchange, err = validateExchange(exchange)
+		exchange, err = h.validateExchange(exchange)
 		if err != nil {
 			h.logger.Warn("Invalid exchange", "exchange", parts[2])
 			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		symbol = strings.ToUpper(parts[3])
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[3])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[3])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -218,7 +210,7 @@ func (h *Handler) HighestPrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if shortID, ok := addrToID[data.Exchange]; ok {
+	if shortID, ok := h.registry.IDForAddress(data.Exchange); ok {
 		data.Exchange = shortID
 	}
 
@@ -236,7 +228,7 @@ func (h *Handler) LowestPrice(w http.ResponseWriter, r *http.Request) {
 		err      error
 	)
 
-	period, err := parsePeriod(query.Get("period"))
+	period, err := app.ParsePeriod(query.Get("period"))
 	if err != nil {
 		h.logger.Warn("Invalid period", "error", err)
 		pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
@@ -245,11 +237,11 @@ func (h *Handler) LowestPrice(w http.ResponseWriter, r *http.Request) {
 
 	switch len(parts) {
 	case 3:
-		symbol = strings.ToUpper(parts[2])
-
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[2])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[2])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -261,17 +253,18 @@ func (h *Handler) LowestPrice(w http.ResponseWriter, r *http.Request) {
 
 	case 4:
 		exchange = parts[2]
-		exchange, err = validateExchange(exchange)
+		exchange, err = h.validateExchange(exchange)
 		if err != nil {
 			h.logger.Warn("Invalid exchange", "exchange", parts[2])
 			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		symbol = strings.ToUpper(parts[3])
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[3])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[3])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -298,7 +291,7 @@ func (h *Handler) LowestPrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if shortID, ok := addrToID[data.Exchange]; ok {
+	if shortID, ok := h.registry.IDForAddress(data.Exchange); ok {
 		data.Exchange = shortID
 	}
 
@@ -316,7 +309,7 @@ func (h *Handler) AveragePrice(w http.ResponseWriter, r *http.Request) {
 		err      error
 	)
 
-	period, err := parsePeriod(query.Get("period"))
+	period, err := app.ParsePeriod(query.Get("period"))
 	if err != nil {
 		h.logger.Warn("Invalid period", "error", err)
 		pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
@@ -325,11 +318,11 @@ func (h *Handler) AveragePrice(w http.ResponseWriter, r *http.Request) {
 
 	switch len(parts) {
 	case 3:
-		symbol = strings.ToUpper(parts[2])
-
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[2])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[2])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -343,17 +336,18 @@ func (h *Handler) AveragePrice(w http.ResponseWriter, r *http.Request) {
 
 	case 4:
 		exchange = parts[2]
-		exchange, err = validateExchange(exchange)
+		exchange, err = h.validateExchange(exchange)
 		if err != nil {
 			h.logger.Warn("Invalid exchange", "exchange", parts[2])
 			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		symbol = strings.ToUpper(parts[3])
-		if err := validateSymbol(symbol); err != nil {
-			h.logger.Warn("Invalid symbol", "symbol", symbol)
-			pkg.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
+		var symErr error
+		symbol, symErr = app.ValidateSymbol(parts[3])
+		if symErr != nil {
+			h.logger.Warn("Invalid symbol", "symbol", parts[3])
+			pkg.WriteErrorJSON(w, http.StatusBadRequest, symErr.Error())
 			return
 		}
 
@@ -380,48 +374,20 @@ func (h *Handler) AveragePrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if shortID, ok := addrToID[data.Exchange]; ok {
+	if shortID, ok := h.registry.IDForAddress(data.Exchange); ok {
 		data.Exchange = shortID
 	}
 
 	pkg.WriteJSON(w, http.StatusOK, data)
 }
 
-func validateSymbol(symbol string) error {
-	if symbol == "" {
-		return app.BadRequest("symbol is required")
-	}
-	if len(symbol) > 10 {
-		return app.BadRequest("symbol is too long")
-	}
-	for _, r := range symbol {
-		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
-			return app.BadRequest("symbol must be alphanumeric")
-		}
-	}
-	return nil
-}
-
-func validateExchange(exchange string) (string, error) {
-	if exchange == "" {
+func (h *Handler) validateExchange(id string) (string, error) {
+	if id == "" {
 		return "", nil
 	}
-	if fullAddr, ok := idToAddr[strings.ToLower(exchange)]; ok {
-		return fullAddr, nil
-	}
-	return "", app.BadRequest("unknown exchange")
-}
-
-func parsePeriod(raw string) (time.Duration, error) {
-	if raw == "" {
-		return 0, nil
-	}
-	period, err := time.ParseDuration(raw)
-	if err != nil {
-		return 0, app.BadRequest("invalid period format")
-	}
-	if period <= 0 {
-		return 0, app.BadRequest("period must be positive")
+	ex, ok := h.registry.Resolve(id)
+	if !ok || !ex.Enabled {
+		return "", app.UnknownExchange("unknown exchange")
 	}
-	return period, nil
+	return ex.Address, nil
 }
@@ -0,0 +1,45 @@
+package httptransport
+
+import (
+	"net/http"
+
+	"marketflow/pkg/auth"
+)
+
+// NewRouter assembles the API mux and applies the auth policy: read
+// endpoints authenticate optionally (an absent key is allowed, a bad one
+// is not), while the mode-switch and admin endpoints are mandatory since
+// flipping the whole service into test or live mode, or changing which
+// exchanges it ingests from, is not something an anonymous caller should
+// be able to do. rpc serves POST /rpc and is typically an
+// *jsonrpc.Handler; it is accepted as http.Handler so this package does
+// not import the jsonrpc package.
+func NewRouter(h *Handler, rpc http.Handler, keys auth.KeyStore) http.Handler {
+	optional := auth.Middleware(auth.Config{Store: keys, Required: false})
+	mandatory := auth.Middleware(auth.Config{Store: keys, Required: true})
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", h.Index)
+	mux.HandleFunc("/healthcheck", h.HealthCheck)
+	mux.Handle("/mode/test", mandatory(http.HandlerFunc(h.SetTestMode)))
+	mux.Handle("/mode/live", mandatory(http.HandlerFunc(h.SetLiveMode)))
+
+	mux.Handle("/ws/subscribe", optional(http.HandlerFunc(h.Subscribe)))
+	mux.Handle("/rpc", optional(rpc))
+
+	mux.Handle("/prices/latest", optional(http.HandlerFunc(h.BatchLatestPrice)))
+	mux.Handle("/prices/latest/", optional(http.HandlerFunc(h.LatestPrice)))
+	mux.Handle("/prices/highest", optional(http.HandlerFunc(h.BatchHighestPrice)))
+	mux.Handle("/prices/highest/", optional(http.HandlerFunc(h.HighestPrice)))
+	mux.Handle("/prices/lowest", optional(http.HandlerFunc(h.BatchLowestPrice)))
+	mux.Handle("/prices/lowest/", optional(http.HandlerFunc(h.LowestPrice)))
+	mux.Handle("/prices/average", optional(http.HandlerFunc(h.BatchAveragePrice)))
+	mux.Handle("/prices/average/", optional(http.HandlerFunc(h.AveragePrice)))
+	mux.Handle("/prices/candles/", optional(http.HandlerFunc(h.Candles)))
+
+	mux.Handle("/admin/exchanges", mandatory(http.HandlerFunc(h.AdminExchanges)))
+	mux.Handle("/admin/exchanges/", mandatory(http.HandlerFunc(h.AdminExchanges)))
+
+	return mux
+}
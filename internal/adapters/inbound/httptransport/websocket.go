@@ -0,0 +1,187 @@
+package httptransport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"marketflow/internal/app/model"
+)
+
+const (
+	// wsWriteBuffer bounds the number of pending outbound messages per
+	// connection. Updates that arrive while the buffer is full are dropped
+	// instead of being allowed to grow the buffer without limit.
+	wsWriteBuffer = 32
+
+	wsPingInterval = 30 * time.Second
+	wsPongWait     = 60 * time.Second
+	wsWriteWait    = 10 * time.Second
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsControlFrame is the JSON control message clients send to manage their
+// subscription: {"action":"subscribe","symbols":[...],"exchanges":[...]}.
+type wsControlFrame struct {
+	Action    string   `json:"action"`
+	Symbols   []string `json:"symbols"`
+	Exchanges []string `json:"exchanges"`
+}
+
+// Subscribe upgrades the connection to a WebSocket and streams live
+// aggregates matching the client's subscription. Clients manage their
+// subscription with JSON control frames; the server pushes MarketData
+// messages as the aggregator produces them.
+func (h *Handler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Warn("WebSocket upgrade failed", "error", err)
+		return
+	}
+
+	h.logger.Info("WebSocket client connected", "remote", r.RemoteAddr)
+	go h.serveSubscriber(conn)
+}
+
+func (h *Handler) serveSubscriber(conn *websocket.Conn) {
+	defer conn.Close()
+
+	var cancel func()
+	defer func() {
+		if cancel != nil {
+			cancel()
+		}
+	}()
+
+	out := make(chan *model.MarketData, wsWriteBuffer)
+	done := make(chan struct{})
+
+	go h.writeSubscriberLoop(conn, out, done)
+
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	for {
+		var frame wsControlFrame
+		if err := conn.ReadJSON(&frame); err != nil {
+			close(done)
+			return
+		}
+
+		switch frame.Action {
+		case "subscribe":
+			if cancel != nil {
+				cancel()
+			}
+			updates, cancelFn := h.svc.Subscribe(frame.Symbols, h.resolveExchanges(frame.Exchanges))
+			cancel = cancelFn
+			go forwardUpdates(updates, out, done)
+
+		case "unsubscribe":
+			if cancel != nil {
+				cancel()
+				cancel = nil
+			}
+
+		default:
+			h.logger.Warn("Unknown WebSocket action", "action", frame.Action)
+		}
+	}
+}
+
+// resolveExchanges maps the short exchange IDs a client subscribes with
+// to the dial addresses the broker filters on internally, the same
+// translation h.validateExchange applies to REST requests. Unknown or
+// disabled IDs are dropped with a warning rather than failing the whole
+// subscription, since the control-frame protocol has no per-entry error
+// reply.
+func (h *Handler) resolveExchanges(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	addresses := make([]string, 0, len(ids))
+	for _, id := range ids {
+		address, err := h.validateExchange(id)
+		if err != nil {
+			h.logger.Warn("Ignoring unknown exchange in subscription", "exchange", id)
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// forwardUpdates copies aggregates from the broker channel into the
+// connection's outbound channel, dropping updates instead of blocking if
+// the writer can't keep up.
+func forwardUpdates(updates <-chan *model.MarketData, out chan<- *model.MarketData, done <-chan struct{}) {
+	for {
+		select {
+		case data, ok := <-updates:
+			if !ok {
+				return
+			}
+			select {
+			case out <- data:
+			default:
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// remapOutbound translates data.Exchange from the dial address the
+// broker filters on back to the short exchange ID the client subscribed
+// with, the same translation every other transport applies at the
+// response boundary. data may be shared with other subscribers via the
+// broker's fan-out, so a copy is mutated rather than data itself.
+func (h *Handler) remapOutbound(data *model.MarketData) *model.MarketData {
+	shortID, ok := h.registry.IDForAddress(data.Exchange)
+	if !ok {
+		return data
+	}
+	remapped := *data
+	remapped.Exchange = shortID
+	return &remapped
+}
+
+// writeSubscriberLoop owns the connection's writes: it pushes outbound
+// updates and periodic pings, and disconnects the client if either write
+// fails or stalls.
+func (h *Handler) writeSubscriberLoop(conn *websocket.Conn, out <-chan *model.MarketData, done chan struct{}) {
+	defer conn.Close()
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data := <-out:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(h.remapOutbound(data)); err != nil {
+				h.logger.Warn("WebSocket write failed, disconnecting client", "error", err)
+				return
+			}
+
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				h.logger.Warn("WebSocket ping failed, disconnecting client", "error", err)
+				return
+			}
+
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package jsonrpc exposes the same query surface as the REST handlers
+// behind a single JSON-RPC 2.0 endpoint (POST /rpc), for clients that
+// prefer one endpoint and a uniform envelope over a REST API.
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"marketflow/internal/app"
+	"marketflow/internal/app/exchange"
+	ports "marketflow/internal/ports/inbound"
+	"marketflow/pkg/logger"
+)
+
+// Standard JSON-RPC 2.0 error codes, as reserved by the spec.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Module-specific error codes, in the -32000 to -32099 block the spec
+// reserves for implementation-defined server errors.
+const (
+	CodeUnknownExchange = -32000
+	CodeUnknownSymbol   = -32001
+	CodeNoData          = -32002
+)
+
+const maxBodySize = 1 << 20 // 1 MiB
+
+// Error is the JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// Handler serves the JSON-RPC facade over the same APIPorts, and the
+// same exchange registry, the REST and WebSocket transports use.
+type Handler struct {
+	svc      ports.APIPorts
+	registry *exchange.Registry
+	logger   *logger.CustomLogger
+}
+
+// NewHandler builds a JSON-RPC Handler backed by svc and registry.
+func NewHandler(svc ports.APIPorts, registry *exchange.Registry, logger *logger.CustomLogger) *Handler {
+	return &Handler{svc: svc, registry: registry, logger: logger}
+}
+
+// resolveExchange maps the short exchange ID a caller sends (e.g.
+// "exchange1") to the dial address APIPorts expects, the same
+// translation httptransport.Handler.validateExchange applies to REST
+// requests, so a disabled or unknown exchange produces the same
+// CodeUnknownExchange here that REST would answer with a 400.
+func (h *Handler) resolveExchange(id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+	ex, ok := h.registry.Resolve(id)
+	if !ok || !ex.Enabled {
+		return "", app.UnknownExchange("unknown exchange")
+	}
+	return ex.Address, nil
+}
+
+// ServeHTTP implements POST /rpc. It accepts either a single request
+// object or, per the spec, a JSON array of request objects (a batch),
+// and always replies with the corresponding shape.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		writeJSON(w, errorResponse(nil, CodeParseError, "failed to read request body"))
+		return
+	}
+	if len(body) > maxBodySize {
+		writeJSON(w, errorResponse(nil, CodeInvalidRequest, "request body too large"))
+		return
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		writeJSON(w, errorResponse(nil, CodeInvalidRequest, "empty request body"))
+		return
+	}
+
+	if trimmed[0] == '[' {
+		var reqs []request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJSON(w, []response{errorResponse(nil, CodeParseError, "invalid JSON")})
+			return
+		}
+		if len(reqs) == 0 {
+			writeJSON(w, []response{errorResponse(nil, CodeInvalidRequest, "batch must not be empty")})
+			return
+		}
+
+		resps := make([]response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = h.dispatch(r.Context(), req)
+		}
+		writeJSON(w, resps)
+		return
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, errorResponse(nil, CodeParseError, "invalid JSON"))
+		return
+	}
+	writeJSON(w, h.dispatch(r.Context(), req))
+}
+
+func (h *Handler) dispatch(ctx context.Context, req request) response {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, CodeInvalidRequest, "jsonrpc must be \"2.0\" and method is required")
+	}
+
+	var params queryParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, CodeInvalidParams, "invalid params")
+		}
+	}
+
+	method, ok := methods[req.Method]
+	if !ok {
+		return errorResponse(req.ID, CodeMethodNotFound, "unknown method: "+req.Method)
+	}
+
+	result, err := method(ctx, h, params)
+	if err != nil {
+		rpcErr := toRPCError(err)
+		h.logger.Warn("RPC method error", "method", req.Method, "error", rpcErr.Message)
+		return response{JSONRPC: "2.0", Error: rpcErr, ID: req.ID}
+	}
+
+	return response{JSONRPC: "2.0", Result: result, ID: req.ID}
+}
+
+// toRPCError maps the application core's AppError to a JSON-RPC error in
+// one place, so REST and RPC stay consistent about what each failure
+// means.
+func toRPCError(err error) *Error {
+	appErr, ok := app.IsAppError(err)
+	if !ok {
+		return &Error{Code: CodeInternalError, Message: "internal error"}
+	}
+
+	switch appErr.Kind {
+	case app.KindUnknownExchange:
+		return &Error{Code: CodeUnknownExchange, Message: appErr.Message}
+	case app.KindUnknownSymbol:
+		return &Error{Code: CodeUnknownSymbol, Message: appErr.Message}
+	case app.KindNoData:
+		return &Error{Code: CodeNoData, Message: appErr.Message}
+	case app.KindBadRequest:
+		return &Error{Code: CodeInvalidParams, Message: appErr.Message}
+	default:
+		return &Error{Code: CodeInternalError, Message: "internal error"}
+	}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) response {
+	return response{JSONRPC: "2.0", Error: &Error{Code: code, Message: message}, ID: id}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,157 @@
+package jsonrpc
+
+import (
+	"context"
+
+	"marketflow/internal/app"
+)
+
+// queryParams covers the params shape of every method this facade
+// exposes. Each method only reads the fields it needs.
+type queryParams struct {
+	Symbol   string `json:"symbol"`
+	Exchange string `json:"exchange"`
+	Period   string `json:"period"`
+	Interval string `json:"interval"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+}
+
+type methodFunc func(ctx context.Context, h *Handler, p queryParams) (interface{}, error)
+
+// methods maps each JSON-RPC method name to its implementation. This is
+// the single place that needs updating when the query surface grows.
+var methods = map[string]methodFunc{
+	"marketflow.getLatest":  getLatest,
+	"marketflow.getHighest": getHighest,
+	"marketflow.getLowest":  getLowest,
+	"marketflow.getAverage": getAverage,
+	"marketflow.getCandles": getCandles,
+}
+
+func getLatest(ctx context.Context, h *Handler, p queryParams) (interface{}, error) {
+	symbol, err := app.ValidateSymbol(p.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := h.resolveExchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if exchange == "" {
+		return h.svc.GetLatestAggregate(ctx, symbol)
+	}
+	return h.svc.GetLatestByExchange(ctx, exchange, symbol)
+}
+
+func getHighest(ctx context.Context, h *Handler, p queryParams) (interface{}, error) {
+	symbol, err := app.ValidateSymbol(p.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := h.resolveExchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	period, err := app.ParsePeriod(p.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case period > 0 && exchange != "":
+		return h.svc.GetHighestByPeriod(ctx, exchange, symbol, period)
+	case period > 0:
+		return h.svc.GetHighestByPeriod(ctx, "", symbol, period)
+	case exchange != "":
+		return h.svc.GetHighestByExchange(ctx, exchange, symbol)
+	default:
+		return h.svc.GetHighestAggregate(ctx, symbol)
+	}
+}
+
+func getLowest(ctx context.Context, h *Handler, p queryParams) (interface{}, error) {
+	symbol, err := app.ValidateSymbol(p.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := h.resolveExchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	period, err := app.ParsePeriod(p.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case period > 0 && exchange != "":
+		return h.svc.GetLowestByPeriod(ctx, exchange, symbol, period)
+	case period > 0:
+		return h.svc.GetLowestByPeriod(ctx, "", symbol, period)
+	case exchange != "":
+		return h.svc.GetLowestByExchange(ctx, exchange, symbol)
+	default:
+		return h.svc.GetLowestAggregate(ctx, symbol)
+	}
+}
+
+func getAverage(ctx context.Context, h *Handler, p queryParams) (interface{}, error) {
+	symbol, err := app.ValidateSymbol(p.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := h.resolveExchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	period, err := app.ParsePeriod(p.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case exchange == "" && period > 0:
+		return nil, app.BadRequest("period requires an exchange")
+	case exchange == "":
+		return h.svc.GetAverageAggregate(ctx, symbol)
+	case period > 0:
+		return h.svc.GetAverageByPeriod(ctx, exchange, symbol, period)
+	default:
+		return h.svc.GetAverageByExchange(ctx, exchange, symbol)
+	}
+}
+
+func getCandles(ctx context.Context, h *Handler, p queryParams) (interface{}, error) {
+	symbol, err := app.ValidateSymbol(p.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := h.resolveExchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := app.ValidIntervals[p.Interval]; !ok {
+		return nil, app.BadRequest("interval must be one of 1m, 5m, 15m, 1h, 4h, 1d")
+	}
+	start, err := app.ParseTimestamp(p.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := app.ParseTimestamp(p.End)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, app.BadRequest("end must be after start")
+	}
+	if end.Sub(start) > app.MaxCandleRange {
+		return nil, app.BadRequest("requested range exceeds the maximum of " + app.MaxCandleRange.String())
+	}
+
+	candles, err := h.svc.GetCandles(ctx, exchange, symbol, p.Interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return app.CandlesOrEmpty(candles), nil
+}
@@ -0,0 +1,10 @@
+package app
+
+// Batch query caps. Kept here rather than in each transport so every
+// transport that offers batch queries enforces the same limit on how much
+// backend work a single request can trigger. Only the REST batch handlers
+// read them; the JSON-RPC batch form is bounded by its body size alone.
+const (
+	MaxBatchSymbols   = 50
+	MaxBatchExchanges = 5
+)
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"marketflow/internal/app/model"
+)
+
+// ValidIntervals is the whitelist of interval strings accepted by the
+// candles endpoint. Anything else is rejected before it reaches the
+// builder or the storage layer.
+var ValidIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// MaxCandleRange caps how wide a [start, end) window a single request may
+// span, regardless of interval, to bound how many raw ticks a request can
+// pull out of storage.
+const MaxCandleRange = 30 * 24 * time.Hour
+
+// TickSource is implemented by the storage adapters that already hold the
+// raw tick history this module ingests (Redis for the hot window,
+// Postgres for anything older). CandleBuilder only needs read access to
+// that history.
+type TickSource interface {
+	Ticks(ctx context.Context, exchange, symbol string, start, end time.Time) ([]*model.MarketData, error)
+}
+
+// CandleBuilder buckets raw ticks into fixed-size OHLCV bars computed
+// on-the-fly from the tick history, rather than maintained incrementally,
+// so it stays correct even when a request asks for a range the service
+// hasn't been running for.
+type CandleBuilder struct {
+	ticks TickSource
+}
+
+// NewCandleBuilder creates a CandleBuilder reading history from source.
+func NewCandleBuilder(source TickSource) *CandleBuilder {
+	return &CandleBuilder{ticks: source}
+}
+
+// Build returns the candles for [start, end) bucketed by interval.
+// Intervals with no ticks are omitted from the result rather than
+// returned as empty bars, so the length of the slice reflects how much
+// data actually exists, not the number of intervals in the range.
+func (b *CandleBuilder) Build(ctx context.Context, exchange, symbol string, interval time.Duration, start, end time.Time) ([]model.Candle, error) {
+	ticks, err := b.ticks.Ticks(ctx, exchange, symbol, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(ticks) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].Timestamp.Before(ticks[j].Timestamp) })
+
+	buckets := make(map[int64]*model.Candle)
+	var order []int64
+
+	for _, t := range ticks {
+		key := t.Timestamp.Truncate(interval).Unix()
+		c, ok := buckets[key]
+		if !ok {
+			c = &model.Candle{
+				Open:      t.Price,
+				High:      t.Price,
+				Low:       t.Price,
+				Close:     t.Price,
+				Timestamp: time.Unix(key, 0).UTC(),
+			}
+			buckets[key] = c
+			order = append(order, key)
+		}
+
+		c.High = max(c.High, t.Price)
+		c.Low = min(c.Low, t.Price)
+		c.Close = t.Price
+		c.Volume += t.Volume
+	}
+
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	candles := make([]model.Candle, len(order))
+	for i, key := range order {
+		candles[i] = *buckets[key]
+	}
+	return candles, nil
+}
+
+// CandlesOrEmpty avoids serializing a nil candle slice as JSON null,
+// since both the REST and JSON-RPC transports expose the candles query
+// and should agree on how an empty range is represented.
+func CandlesOrEmpty(candles []model.Candle) []model.Candle {
+	if candles == nil {
+		return []model.Candle{}
+	}
+	return candles
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"marketflow/internal/app/model"
+)
+
+type stubTickSource struct {
+	ticks []*model.MarketData
+}
+
+func (s *stubTickSource) Ticks(ctx context.Context, exchange, symbol string, start, end time.Time) ([]*model.MarketData, error) {
+	return s.ticks, nil
+}
+
+func tickAt(ts time.Time, price, volume float64) *model.MarketData {
+	return &model.MarketData{Price: price, Volume: volume, Timestamp: ts}
+}
+
+func TestCandleBuilderBucketsByInterval(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &stubTickSource{ticks: []*model.MarketData{
+		tickAt(base, 100, 1),
+		tickAt(base.Add(30*time.Second), 110, 2),
+		tickAt(base.Add(time.Minute), 90, 1),
+		tickAt(base.Add(90*time.Second), 95, 1),
+	}}
+	builder := NewCandleBuilder(source)
+
+	candles, err := builder.Build(context.Background(), "exchange1:40101", "BTC", time.Minute, base, base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(candles))
+	}
+
+	first := candles[0]
+	if first.Open != 100 || first.High != 110 || first.Low != 100 || first.Close != 110 || first.Volume != 3 {
+		t.Fatalf("unexpected first bucket: %+v", first)
+	}
+	if !first.Timestamp.Equal(base) {
+		t.Fatalf("expected first bucket timestamp %v, got %v", base, first.Timestamp)
+	}
+
+	second := candles[1]
+	if second.Open != 90 || second.High != 95 || second.Low != 90 || second.Close != 95 || second.Volume != 2 {
+		t.Fatalf("unexpected second bucket: %+v", second)
+	}
+}
+
+func TestCandleBuilderOmitsEmptyRange(t *testing.T) {
+	source := &stubTickSource{}
+	builder := NewCandleBuilder(source)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles, err := builder.Build(context.Background(), "exchange1:40101", "BTC", time.Minute, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if candles != nil {
+		t.Fatalf("expected nil candles for an empty tick range, got %+v", candles)
+	}
+}
+
+func TestCandlesOrEmpty(t *testing.T) {
+	if got := CandlesOrEmpty(nil); got == nil || len(got) != 0 {
+		t.Fatalf("expected an empty, non-nil slice for nil input, got %#v", got)
+	}
+
+	want := []model.Candle{{Open: 1, Close: 1}}
+	if got := CandlesOrEmpty(want); len(got) != 1 {
+		t.Fatalf("expected passthrough for a non-nil slice, got %#v", got)
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import "net/http"
+
+// ErrorKind classifies an AppError beyond its HTTP status code, so that
+// transports other than REST (the JSON-RPC facade) can map the same
+// error to their own error space without string-matching messages.
+type ErrorKind int
+
+const (
+	KindBadRequest ErrorKind = iota
+	KindUnknownExchange
+	KindUnknownSymbol
+	KindNoData
+	KindInternal
+)
+
+// AppError is the error type returned by the application core for any
+// failure a transport should report to the caller rather than just log.
+// Code is the HTTP status the REST handlers write directly; Kind lets
+// other transports derive their own error code from the same value.
+type AppError struct {
+	Kind    ErrorKind
+	Code    int
+	Message string
+}
+
+func (e *AppError) Error() string { return e.Message }
+
+// BadRequest reports a malformed or invalid request.
+func BadRequest(message string) *AppError {
+	return &AppError{Kind: KindBadRequest, Code: http.StatusBadRequest, Message: message}
+}
+
+// UnknownExchange reports a request for an exchange the service has no
+// reader for.
+func UnknownExchange(message string) *AppError {
+	return &AppError{Kind: KindUnknownExchange, Code: http.StatusBadRequest, Message: message}
+}
+
+// UnknownSymbol reports a request for a symbol the service has never
+// seen an aggregate for.
+func UnknownSymbol(message string) *AppError {
+	return &AppError{Kind: KindUnknownSymbol, Code: http.StatusBadRequest, Message: message}
+}
+
+// NoData reports a well-formed request for which no aggregate exists yet
+// (e.g. a period with no ticks).
+func NoData(message string) *AppError {
+	return &AppError{Kind: KindNoData, Code: http.StatusNotFound, Message: message}
+}
+
+// Internal reports an unexpected failure inside the application core.
+func Internal(message string) *AppError {
+	return &AppError{Kind: KindInternal, Code: http.StatusInternalServerError, Message: message}
+}
+
+// IsAppError reports whether err is an *AppError produced by this
+// package, so callers can distinguish expected, user-facing failures
+// from bugs.
+func IsAppError(err error) (*AppError, bool) {
+	ae, ok := err.(*AppError)
+	return ae, ok
+}
@@ -0,0 +1,172 @@
+// Package exchange holds the runtime registry of exchanges the service
+// ingests from, replacing the fixed three-exchange wiring with a set the
+// ingestion subsystem and the admin API can both change at runtime.
+package exchange
+
+import (
+	"strings"
+	"sync"
+
+	"marketflow/internal/app"
+)
+
+// Exchange describes one venue the ingestion subsystem reads ticks from.
+type Exchange struct {
+	ID       string `json:"id"`
+	Address  string `json:"address"`
+	Protocol string `json:"protocol"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// EventKind distinguishes the two changes a Registry can emit.
+type EventKind int
+
+const (
+	EventRegistered EventKind = iota
+	EventUnregistered
+)
+
+// Event is published on every registration change so the ingestion
+// subsystem can spin up or tear down TCP readers without a restart.
+type Event struct {
+	Kind     EventKind
+	Exchange Exchange
+}
+
+// Reader is the read side of Registry: what the ingestion subsystem
+// needs to discover the current exchange set and react to changes.
+type Reader interface {
+	List() []Exchange
+	Watch() (<-chan Event, func())
+}
+
+// Registry is a concurrency-safe, mutable set of exchanges loaded from
+// config at startup and changed at runtime via the admin API.
+type Registry struct {
+	mu        sync.RWMutex
+	exchanges map[string]Exchange
+	watchers  map[int]chan Event
+	nextID    int
+}
+
+// NewRegistry creates a Registry seeded with the given exchanges, e.g.
+// loaded from config at startup.
+func NewRegistry(initial []Exchange) *Registry {
+	r := &Registry{
+		exchanges: make(map[string]Exchange, len(initial)),
+		watchers:  make(map[int]chan Event),
+	}
+	for _, ex := range initial {
+		ex.ID = strings.ToLower(ex.ID)
+		r.exchanges[ex.ID] = ex
+	}
+	return r
+}
+
+// Register adds or updates an exchange and notifies watchers.
+func (r *Registry) Register(ex Exchange) error {
+	ex.ID = strings.ToLower(ex.ID)
+	if ex.ID == "" {
+		return app.BadRequest("exchange id is required")
+	}
+	if ex.Address == "" {
+		return app.BadRequest("exchange address is required")
+	}
+	if ex.Protocol == "" {
+		return app.BadRequest("exchange protocol is required")
+	}
+
+	r.mu.Lock()
+	r.exchanges[ex.ID] = ex
+	r.mu.Unlock()
+
+	r.publish(Event{Kind: EventRegistered, Exchange: ex})
+	return nil
+}
+
+// Unregister removes an exchange by ID and notifies watchers. It is not
+// an error to unregister an ID that is not present.
+func (r *Registry) Unregister(id string) {
+	id = strings.ToLower(id)
+
+	r.mu.Lock()
+	ex, ok := r.exchanges[id]
+	if ok {
+		delete(r.exchanges, id)
+	}
+	r.mu.Unlock()
+
+	if ok {
+		r.publish(Event{Kind: EventUnregistered, Exchange: ex})
+	}
+}
+
+// List returns a snapshot of every registered exchange.
+func (r *Registry) List() []Exchange {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Exchange, 0, len(r.exchanges))
+	for _, ex := range r.exchanges {
+		out = append(out, ex)
+	}
+	return out
+}
+
+// Resolve looks up an exchange by its short ID (case-insensitive).
+func (r *Registry) Resolve(id string) (Exchange, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ex, ok := r.exchanges[strings.ToLower(id)]
+	return ex, ok
+}
+
+// IDForAddress resolves a dial address back to its short exchange ID, the
+// inverse lookup handler responses need to echo data.Exchange as the ID
+// clients asked for rather than the raw address.
+func (r *Registry) IDForAddress(address string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, ex := range r.exchanges {
+		if ex.Address == address {
+			return ex.ID, true
+		}
+	}
+	return "", false
+}
+
+// Watch subscribes to registration changes and returns a channel of
+// events plus a cancel func that unsubscribes and closes the channel,
+// mirroring app.Broker.Subscribe.
+func (r *Registry) Watch() (<-chan Event, func()) {
+	ch := make(chan Event, 8)
+
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.watchers[id] = ch
+	r.mu.Unlock()
+
+	cancel := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if _, ok := r.watchers[id]; !ok {
+			return
+		}
+		delete(r.watchers, id)
+		close(ch)
+	}
+
+	return ch, cancel
+}
+
+func (r *Registry) publish(evt Event) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, ch := range r.watchers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
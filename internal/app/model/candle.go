@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Candle is a single OHLCV bar produced by bucketing raw aggregates into a
+// fixed interval, as used by the candles endpoint and any charting client.
+type Candle struct {
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"sync"
+
+	"marketflow/internal/app/model"
+)
+
+// subscriberBufferSize bounds the per-subscriber outbound queue. A slow
+// consumer that falls this far behind is dropped rather than allowed to
+// stall the aggregator's publish path.
+const subscriberBufferSize = 64
+
+type subscriber struct {
+	ch        chan *model.MarketData
+	symbols   map[string]struct{}
+	exchanges map[string]struct{}
+}
+
+func (s *subscriber) matches(data *model.MarketData) bool {
+	if len(s.symbols) > 0 {
+		if _, ok := s.symbols[data.Symbol]; !ok {
+			return false
+		}
+	}
+	if len(s.exchanges) > 0 {
+		if _, ok := s.exchanges[data.Exchange]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Broker fans out aggregates produced by the aggregator to subscribers
+// filtered by symbol and exchange. It is safe for concurrent use.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[int]*subscriber
+	nextID      int
+}
+
+// NewBroker creates an empty Broker ready to accept subscribers.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]*subscriber)}
+}
+
+// Subscribe registers a new subscriber and returns its channel plus a
+// cancel func that removes it and closes the channel. It implements the
+// inbound.APIPorts.Subscribe contract.
+func (b *Broker) Subscribe(symbols, exchanges []string) (<-chan *model.MarketData, func()) {
+	sub := &subscriber{
+		ch:        make(chan *model.MarketData, subscriberBufferSize),
+		symbols:   toSet(symbols),
+		exchanges: toSet(exchanges),
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[id]; !ok {
+			return
+		}
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+
+	return sub.ch, cancel
+}
+
+// Publish delivers data to every subscriber whose filter matches it. A
+// subscriber whose channel is full is dropped rather than blocking the
+// caller; the caller (the aggregator) must never wait on this call.
+func (b *Broker) Publish(data *model.MarketData) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, sub := range b.subscribers {
+		if !sub.matches(data) {
+			continue
+		}
+		select {
+		case sub.ch <- data:
+		default:
+			go b.dropSlowSubscriber(id)
+		}
+	}
+}
+
+func (b *Broker) dropSlowSubscriber(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, ok := b.subscribers[id]
+	if !ok {
+		return
+	}
+	delete(b.subscribers, id)
+	close(sub.ch)
+}
+
+func toSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidateSymbol normalizes raw to upper case and checks it is a
+// non-empty, reasonably short alphanumeric ticker. It returns the
+// normalized form so callers don't need a separate uppercasing step
+// before validating.
+func ValidateSymbol(raw string) (string, error) {
+	symbol := strings.ToUpper(raw)
+	if symbol == "" {
+		return "", BadRequest("symbol is required")
+	}
+	if len(symbol) > 10 {
+		return "", BadRequest("symbol is too long")
+	}
+	for _, r := range symbol {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "", BadRequest("symbol must be alphanumeric")
+		}
+	}
+	return symbol, nil
+}
+
+// ParsePeriod parses an optional lookback window like "5m" or "1h". An
+// empty string means "no period requested" and returns a zero duration
+// with no error.
+func ParsePeriod(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	period, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, BadRequest("invalid period format")
+	}
+	if period <= 0 {
+		return 0, BadRequest("period must be positive")
+	}
+	return period, nil
+}
+
+// ParseTimestamp accepts either an RFC3339 timestamp or a unix
+// millisecond integer, matching the two formats clients commonly
+// already send for period-bounded queries.
+func ParseTimestamp(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, BadRequest("timestamp is required")
+	}
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, BadRequest("timestamp must be RFC3339 or unix milliseconds")
+	}
+	return t.UTC(), nil
+}
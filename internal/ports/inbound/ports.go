@@ -0,0 +1,54 @@
+// Package inbound defines the ports through which inbound adapters (HTTP,
+// WebSocket, RPC, ...) drive the application core.
+package inbound
+
+import (
+	"context"
+	"time"
+
+	"marketflow/internal/app/model"
+)
+
+// APIPorts is the surface the application core exposes to inbound
+// transports. Transports depend only on this interface so they can be
+// swapped or added without reaching into the core's internals.
+type APIPorts interface {
+	HealthCheck() error
+	SetMode(mode string)
+
+	GetLatestAggregate(ctx context.Context, symbol string) (*model.MarketData, error)
+	GetLatestByExchange(ctx context.Context, exchange, symbol string) (*model.MarketData, error)
+
+	GetHighestAggregate(ctx context.Context, symbol string) (*model.MarketData, error)
+	GetHighestByExchange(ctx context.Context, exchange, symbol string) (*model.MarketData, error)
+	GetHighestByPeriod(ctx context.Context, exchange, symbol string, period time.Duration) (*model.MarketData, error)
+
+	GetLowestAggregate(ctx context.Context, symbol string) (*model.MarketData, error)
+	GetLowestByExchange(ctx context.Context, exchange, symbol string) (*model.MarketData, error)
+	GetLowestByPeriod(ctx context.Context, exchange, symbol string, period time.Duration) (*model.MarketData, error)
+
+	GetAverageAggregate(ctx context.Context, symbol string) (*model.MarketData, error)
+	GetAverageByExchange(ctx context.Context, exchange, symbol string) (*model.MarketData, error)
+	GetAverageByPeriod(ctx context.Context, exchange, symbol string, period time.Duration) (*model.MarketData, error)
+
+	// GetLatestBatch fans out a latest-price lookup over the cartesian
+	// product of symbols and exchanges (an empty exchanges slice means
+	// "aggregated across all exchanges", keyed by ""). It returns
+	// whatever data it could fetch, plus a per-"symbol:exchange" errors
+	// map for the lookups that failed, rather than failing the whole
+	// call when only some keys have no data.
+	GetLatestBatch(ctx context.Context, symbols, exchanges []string) (data map[string]map[string]*model.MarketData, errs map[string]string, err error)
+
+	// GetCandles returns OHLCV bars for symbol on exchange, bucketed by
+	// interval, covering [start, end). exchange may be empty to aggregate
+	// across all exchanges. interval must be one of app.ValidIntervals and
+	// end.Sub(start) must not exceed app.MaxCandleRange.
+	GetCandles(ctx context.Context, exchange, symbol, interval string, start, end time.Time) ([]model.Candle, error)
+
+	// Subscribe registers interest in live aggregates for the given symbols
+	// and exchanges and returns a channel that receives a *model.MarketData
+	// every time the aggregator produces a matching update, along with a
+	// cancel func that unregisters the subscription and closes the channel.
+	// An empty symbols or exchanges slice matches everything.
+	Subscribe(symbols, exchanges []string) (<-chan *model.MarketData, cancel func())
+}
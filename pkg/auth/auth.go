@@ -0,0 +1,28 @@
+// Package auth implements the HMAC-signed request authentication used by
+// the HTTP API, modeled on the signed-request scheme of Bybit/Binance
+// style clients: every request carries X-API-KEY, X-TIMESTAMP, and
+// X-SIGNATURE headers, where the signature is an HMAC-SHA256 of the
+// timestamp, method, path, raw query, and body, keyed by the API key's
+// secret.
+package auth
+
+import "context"
+
+// KeyStore resolves an API key ID to its shared secret. It is interface-
+// driven so the initial file/env-backed implementation can later be
+// swapped for a database-backed one without touching the middleware.
+type KeyStore interface {
+	Secret(keyID string) (secret string, ok bool)
+}
+
+type contextKey int
+
+const keyIDContextKey contextKey = 0
+
+// KeyIDFromContext returns the API key ID that authenticated the current
+// request, if any. Handlers behind an optional-auth policy must check ok
+// before relying on the key ID.
+func KeyIDFromContext(ctx context.Context) (keyID string, ok bool) {
+	keyID, ok = ctx.Value(keyIDContextKey).(string)
+	return keyID, ok
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StaticKeyStore is a KeyStore backed by an in-memory map, loaded once
+// from a file or the environment. It covers the initial deployment; a
+// database-backed KeyStore can slot in later behind the same interface.
+type StaticKeyStore struct {
+	secrets map[string]string
+}
+
+// NewStaticKeyStore wraps a pre-built keyID -> secret map.
+func NewStaticKeyStore(secrets map[string]string) *StaticKeyStore {
+	return &StaticKeyStore{secrets: secrets}
+}
+
+// LoadKeyStoreFile reads a JSON file of the form {"keyID": "secret", ...}.
+func LoadKeyStoreFile(path string) (*StaticKeyStore, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: reading key store file: %w", err)
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(raw, &secrets); err != nil {
+		return nil, fmt.Errorf("auth: parsing key store file: %w", err)
+	}
+
+	return NewStaticKeyStore(secrets), nil
+}
+
+// LoadKeyStoreEnv builds a KeyStore from environment variables prefixed
+// with prefix, e.g. MARKETFLOW_APIKEY_<KEYID>=<secret>.
+func LoadKeyStoreEnv(prefix string) *StaticKeyStore {
+	secrets := make(map[string]string)
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		keyID := strings.TrimPrefix(name, prefix)
+		if keyID == "" {
+			continue
+		}
+		secrets[keyID] = value
+	}
+	return NewStaticKeyStore(secrets)
+}
+
+// Secret implements KeyStore.
+func (s *StaticKeyStore) Secret(keyID string) (string, bool) {
+	secret, ok := s.secrets[keyID]
+	return secret, ok
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"marketflow/pkg"
+)
+
+// DefaultWindow is the maximum allowed drift between X-TIMESTAMP and the
+// server's clock, chosen to absorb normal clock skew while still closing
+// the replay window quickly.
+const DefaultWindow = 5 * time.Second
+
+// maxBodySize bounds how much of the request body the middleware will
+// buffer to compute the signature, mirroring the cap the JSON-RPC
+// handler applies to its own body read.
+const maxBodySize = 1 << 20 // 1 MiB
+
+// Config controls one Middleware instance.
+type Config struct {
+	// Store resolves an API key ID to its secret.
+	Store KeyStore
+	// Window bounds how far X-TIMESTAMP may drift from now. Defaults to
+	// DefaultWindow if zero.
+	Window time.Duration
+	// Required rejects unauthenticated requests outright. When false,
+	// requests with no auth headers at all are let through unauthenticated;
+	// requests that do present headers are still fully verified.
+	Required bool
+}
+
+// Middleware verifies the X-API-KEY/X-TIMESTAMP/X-SIGNATURE headers and,
+// on success, injects the authenticated key ID into the request context.
+// The signature must equal hex(HMAC-SHA256(secret, timestamp + method +
+// path + rawQuery + body)).
+func Middleware(cfg Config) func(http.Handler) http.Handler {
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID := r.Header.Get("X-API-KEY")
+			timestamp := r.Header.Get("X-TIMESTAMP")
+			signature := r.Header.Get("X-SIGNATURE")
+
+			if keyID == "" && timestamp == "" && signature == "" {
+				if cfg.Required {
+					unauthorized(w, "missing authentication headers")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if keyID == "" || timestamp == "" || signature == "" {
+				unauthorized(w, "incomplete authentication headers")
+				return
+			}
+
+			secret, ok := cfg.Store.Secret(keyID)
+			if !ok {
+				unauthorized(w, "unknown API key")
+				return
+			}
+
+			tsMillis, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				unauthorized(w, "invalid timestamp")
+				return
+			}
+			skew := time.Since(time.UnixMilli(tsMillis))
+			if skew < 0 {
+				skew = -skew
+			}
+			if skew > window {
+				unauthorized(w, "timestamp outside allowed window")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+			if err != nil {
+				unauthorized(w, "failed to read request body")
+				return
+			}
+			if len(body) > maxBodySize {
+				pkg.WriteErrorJSON(w, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			payload := timestamp + r.Method + r.URL.Path + r.URL.RawQuery + string(body)
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(payload))
+			expected := hex.EncodeToString(mac.Sum(nil))
+
+			if !hmac.Equal([]byte(expected), []byte(signature)) {
+				unauthorized(w, "invalid signature")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), keyIDContextKey, keyID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	pkg.WriteErrorJSON(w, http.StatusUnauthorized, message)
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+func sign(secret, timestamp, method, path, rawQuery, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + method + path + rawQuery + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func signedRequest(t *testing.T, secret string, ts time.Time, method, path, body string) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(ts.UnixMilli(), 10)
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-API-KEY", "key1")
+	req.Header.Set("X-TIMESTAMP", timestamp)
+	req.Header.Set("X-SIGNATURE", sign(secret, timestamp, method, req.URL.Path, req.URL.RawQuery, body))
+	return req
+}
+
+func testMiddleware(required bool) func(http.Handler) http.Handler {
+	store := NewStaticKeyStore(map[string]string{"key1": testSecret})
+	return Middleware(Config{Store: store, Required: required})
+}
+
+func TestMiddlewareAcceptsValidSignature(t *testing.T) {
+	mw := testMiddleware(true)
+	req := signedRequest(t, testSecret, time.Now(), http.MethodGet, "/prices/latest/BTC", "")
+
+	rr := httptest.NewRecorder()
+	called := false
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		keyID, ok := KeyIDFromContext(r.Context())
+		if !ok || keyID != "key1" {
+			t.Errorf("expected authenticated key ID %q, got %q (ok=%v)", "key1", keyID, ok)
+		}
+	})).ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatal("expected downstream handler to be called for a valid signature")
+	}
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestMiddlewareRejectsInvalidSignature(t *testing.T) {
+	mw := testMiddleware(true)
+	req := signedRequest(t, testSecret, time.Now(), http.MethodGet, "/prices/latest/BTC", "")
+	req.Header.Set("X-SIGNATURE", "0000")
+
+	rr := httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("downstream handler must not run for an invalid signature")
+	})).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rr.Code)
+	}
+}
+
+func TestMiddlewareRejectsOutsideWindow(t *testing.T) {
+	mw := testMiddleware(true)
+	req := signedRequest(t, testSecret, time.Now().Add(-time.Hour), http.MethodGet, "/prices/latest/BTC", "")
+
+	rr := httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("downstream handler must not run for a stale timestamp")
+	})).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rr.Code)
+	}
+}
+
+func TestMiddlewareAllowsUnauthenticatedWhenNotRequired(t *testing.T) {
+	mw := testMiddleware(false)
+	req := httptest.NewRequest(http.MethodGet, "/prices/latest/BTC", nil)
+
+	rr := httptest.NewRecorder()
+	called := false
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})).ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatal("expected downstream handler to run when auth is optional and no headers are sent")
+	}
+}